@@ -0,0 +1,64 @@
+package envconfig
+
+import "fmt"
+
+// ParseError reports the failure to populate a single field: which env var (for ProcessFlags, which
+// flag name) and struct field were involved, what type the field was, the raw value that was
+// rejected, and the underlying error.
+// Callers can errors.As a returned error (or one held in a MultiError) to a *ParseError to inspect
+// the offending field programmatically.
+type ParseError struct {
+	KeyName   string
+	FieldName string
+	TypeName  string
+	Value     string
+	Err       error
+}
+
+func (e *ParseError) Error() string {
+	if e.KeyName == "" {
+		return fmt.Sprintf("envconfig: field %s (%s): %v", e.FieldName, e.TypeName, e.Err)
+	}
+
+	return fmt.Sprintf("envconfig: field %s (%s), env %s=%q: %v", e.FieldName, e.TypeName, e.KeyName, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError aggregates every ParseError produced while processing a spec, so a caller gets one
+// actionable report of all misconfigured variables instead of debugging them one at a time.
+type MultiError struct {
+	Errors []error
+}
+
+func (m *MultiError) Error() string {
+	if len(m.Errors) == 1 {
+		return m.Errors[0].Error()
+	}
+
+	s := fmt.Sprintf("envconfig: %d errors occurred:", len(m.Errors))
+	for _, err := range m.Errors {
+		s += fmt.Sprintf("\n\t* %v", err)
+	}
+
+	return s
+}
+
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// combineErrors turns a slice of per-field errors into a single error suitable for returning from
+// Process/ProcessFlags: nil if empty, the lone error if there's exactly one, otherwise a MultiError.
+func combineErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return &MultiError{Errors: errs}
+	}
+}
@@ -1,6 +1,7 @@
 package envconfig
 
 import (
+	"encoding"
 	"errors"
 	"flag"
 	"fmt"
@@ -8,9 +9,58 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 	"unsafe"
 )
 
+// durationType lets convertScalar special-case time.Duration, which is otherwise indistinguishable
+// from a plain int64 by reflect.Kind alone.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// defaultSeparator is used to split slice and map env values when no `separator` tag is given.
+const defaultSeparator = ","
+
+// customSetter returns a function that hands raw env values to fld's own Set or UnmarshalText
+// method, and true, if fld (or a pointer to it) implements Value or encoding.TextUnmarshaler. A nil
+// pointer field is only allocated once that's confirmed, so a pointer field of some unrelated type
+// (e.g. *int) is left untouched and falls through to convertScalar instead.
+func customSetter(fld reflect.Value) (func(string) error, bool) {
+	if fld.Kind() == reflect.Ptr {
+		elemType := fld.Type().Elem()
+		if !reflect.PointerTo(elemType).Implements(valueType) && !reflect.PointerTo(elemType).Implements(textUnmarshalerType) {
+			return nil, false
+		}
+
+		if fld.IsNil() {
+			fld.Set(reflect.New(elemType))
+		}
+
+		return setterFor(fld)
+	}
+
+	if !fld.CanAddr() {
+		return nil, false
+	}
+	if !reflect.PointerTo(fld.Type()).Implements(valueType) && !reflect.PointerTo(fld.Type()).Implements(textUnmarshalerType) {
+		return nil, false
+	}
+
+	return setterFor(fld.Addr())
+}
+
+// setterFor assumes candidate already implements Value or encoding.TextUnmarshaler and returns the
+// bound setter function.
+func setterFor(candidate reflect.Value) (func(string) error, bool) {
+	if setter, ok := candidate.Interface().(Value); ok {
+		return setter.Set, true
+	}
+	if tu, ok := candidate.Interface().(encoding.TextUnmarshaler); ok {
+		return func(raw string) error { return tu.UnmarshalText([]byte(raw)) }, true
+	}
+
+	return nil, false
+}
+
 func handleError(s string, showError bool) error {
 	if showError {
 		fmt.Fprintln(os.Stderr, s)
@@ -19,6 +69,58 @@ func handleError(s string, showError bool) error {
 	return errors.New(s)
 }
 
+// fieldParseError builds a *ParseError for a field-level failure and, if showErrors is set, prints
+// it immediately so a slow individual failure is still visible while the rest of the spec is
+// processed.
+func fieldParseError(keyName, fieldName, typeName, value string, err error, showErrors bool) *ParseError {
+	pe := &ParseError{KeyName: keyName, FieldName: fieldName, TypeName: typeName, Value: value, Err: err}
+	if showErrors {
+		fmt.Fprintln(os.Stderr, pe.Error())
+	}
+
+	return pe
+}
+
+// nestedPrefix derives the env var prefix a nested struct field contributes to its own
+// children, joining it onto whatever prefix was already accumulated by its ancestors.
+// The derived segment is the field name uppercased, unless overridden by an `envprefix` tag.
+func nestedPrefix(prefix string, typField reflect.StructField) string {
+	next := strings.ToUpper(typField.Name)
+	if tag, ok := typField.Tag.Lookup("envprefix"); ok {
+		next = tag
+	}
+
+	if prefix == "" {
+		return next
+	}
+
+	return prefix + "_" + next
+}
+
+// prefixedEnvTag joins an accumulated prefix onto a field's own `env` tag.
+func prefixedEnvTag(prefix, envTag string) string {
+	if prefix == "" {
+		return envTag
+	}
+
+	return prefix + "_" + envTag
+}
+
+// flagPrefix is the ProcessFlags equivalent of nestedPrefix: flag names are conventionally
+// lowercase and dot-joined rather than uppercase and underscore-joined.
+func flagPrefix(prefix string, typField reflect.StructField) string {
+	next := strings.ToLower(typField.Name)
+	if tag, ok := typField.Tag.Lookup("flagprefix"); ok {
+		next = tag
+	}
+
+	if prefix == "" {
+		return next
+	}
+
+	return prefix + "." + next
+}
+
 // ProcessFlags works mostly the same as Process, but expects values in the spec to be provided
 // as command line flags instead of as environment variables
 // Differences:
@@ -26,6 +128,9 @@ func handleError(s string, showError bool) error {
 // 2. Flag name is automatically determined by lowercasing the field name. This can be overriden by providing a "flag" tag
 // 3. A "usage" tag can be provided to add usage instructions
 // 4. showErrors support not available. Errors will never be printed to stdErr
+//
+// Like Process, every unsupported field is recorded rather than aborting registration early, and the
+// collected errors come back as a single *ParseError or, if there's more than one, a *MultiError.
 func ProcessFlags(spec interface{}) error {
 	// Check that spec is a pointer to struct (otherwise it won't be mutable)
 	if reflect.ValueOf(spec).Kind() != reflect.Ptr {
@@ -35,19 +140,51 @@ func ProcessFlags(spec interface{}) error {
 	// Get the concrete, specific instance pointed to by "spec"
 	concrete := reflect.ValueOf(spec).Elem()
 
+	errs := processFlagsStruct(concrete, "")
+	if err := combineErrors(errs); err != nil {
+		return err
+	}
+
+	flag.Parse()
+	return nil
+}
+
+// processFlagsStruct registers flags for a single struct level, recursing into nested structs
+// (or pointers to structs) so that composed config structs can each bring their own flags.
+func processFlagsStruct(concrete reflect.Value, prefix string) []error {
+	var errs []error
+
 	// We iterate over the struct, and extract the type information from each field along with the tags
 	// supplied. We then grab an unsafe pointer to each field in the struct and cast it to the correct
 	// pointer type for that field. Then that "safe" pointer is used as the target for the call to flag
 	for i := 0; i < concrete.NumField(); i++ {
 		typ := concrete.Type().Field(i)
+		fld := concrete.Field(i)
+
+		// Nested structs contribute their own flags under a derived prefix rather than being
+		// flags themselves
+		if fld.Kind() == reflect.Struct {
+			errs = append(errs, processFlagsStruct(fld, flagPrefix(prefix, typ))...)
+			continue
+		}
+		if fld.Kind() == reflect.Ptr && fld.Type().Elem().Kind() == reflect.Struct {
+			if fld.IsNil() {
+				fld.Set(reflect.New(fld.Type().Elem()))
+			}
+			errs = append(errs, processFlagsStruct(fld.Elem(), flagPrefix(prefix, typ))...)
+			continue
+		}
+
 		defaultVal, usageVal := typ.Tag.Get("default"), typ.Tag.Get("usage")
 		name := strings.ToLower(typ.Name)
 		if fName, ok := typ.Tag.Lookup("flag"); ok {
 			// explicit flag name was provided, so it overrides the default of the lowercased field name
 			name = fName
 		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
 
-		fld := concrete.Field(i)
 		uptr := unsafe.Pointer(fld.UnsafeAddr())
 		switch fld.Type().Kind() {
 		case reflect.Int64:
@@ -69,12 +206,36 @@ func ProcessFlags(spec interface{}) error {
 			ptr := (*bool)(uptr)
 			flag.BoolVar(ptr, name, false, usageVal) // We set the default to false so that this is only true when set
 		default:
-			return handleError(fmt.Sprintf("The type '%s' of the field '%s' is not supported", fld.Type().Kind(), typ.Name), false)
+			errs = append(errs, fieldParseError(name, typ.Name, fld.Type().String(), "", errors.New("type is not supported"), false))
 		}
 	}
 
-	flag.Parse()
-	return nil
+	return errs
+}
+
+// config holds the options ProcessWith assembles from its Option list.
+type config struct {
+	lookuper   Lookuper
+	prefix     string
+	showErrors bool
+}
+
+// Option configures ProcessWith.
+type Option func(*config)
+
+// WithLookuper overrides where ProcessWith reads raw values from. Defaults to OSLookuper.
+func WithLookuper(l Lookuper) Option {
+	return func(c *config) { c.lookuper = l }
+}
+
+// WithPrefix behaves like ProcessWithPrefix's prefix argument.
+func WithPrefix(prefix string) Option {
+	return func(c *config) { c.prefix = prefix }
+}
+
+// WithShowErrors behaves like Process's showErrors argument.
+func WithShowErrors(showErrors bool) Option {
+	return func(c *config) { c.showErrors = showErrors }
 }
 
 // Process reads a struct with fields and some specific tags and reaches into the runtime environment to fill in values
@@ -82,91 +243,299 @@ func ProcessFlags(spec interface{}) error {
 // Additionally, you can set 2 tags to control the behavior of the configuration loader:
 // 1. `default`: Allows you to set a default value for the field in the event the environment variable is not set
 // 2. `required`: Causes a panic if no value is defined in the environment variable specified by `env` tag
+//
+// Fields whose kind is a struct (or a pointer to a struct) are walked recursively instead of being
+// read directly; see ProcessWithPrefix for how their env vars are named.
+//
+// Slices and maps are supported too: the raw value is split on a separator (a comma by default,
+// overridable with a `separator` tag) into elements for a slice, or "key:value" pairs for a map, with
+// each element/key/value converted using the same scalar rules as a plain field. time.Duration
+// fields are parsed with time.ParseDuration rather than as a plain int64.
+//
+// Process does not abort on the first bad field: every field is attempted, and the resulting error,
+// if any, is either a lone *ParseError or a *MultiError wrapping one ParseError per failed field.
+//
+// A `validate` tag runs a comma-separated list of built-in predicates (nonzero, min=, max=, oneof=,
+// url, email) against a field right after it's converted. Once every field in a struct has been set,
+// Process also calls Validate() on it (and on each nested struct) if it implements Validatable,
+// aggregating that error into the same report.
+//
+// Process is a thin wrapper around ProcessWith using OSLookuper; use ProcessWith directly for
+// control over where values are read from.
 func Process(spec interface{}, showErrors bool) error {
+	return ProcessWith(spec, WithShowErrors(showErrors))
+}
+
+// ProcessWithPrefix works the same as Process, but prepends prefix (joined with an underscore) to
+// every `env` tag encountered, including those of nested structs. This is the same mechanism nested
+// struct fields use to build on their parent's prefix, so it doubles as the entrypoint for giving an
+// entire spec a prefix of its own, e.g. ProcessWithPrefix("MYAPP", &cfg, true) reads MYAPP_HOST instead
+// of HOST.
+func ProcessWithPrefix(prefix string, spec interface{}, showErrors bool) error {
+	return ProcessWith(spec, WithPrefix(prefix), WithShowErrors(showErrors))
+}
+
+// ProcessWith works like Process, but the source of raw values and the prefix/showErrors behavior
+// are configured via opts instead of fixed arguments. With no WithLookuper option, it reads from the
+// OS environment exactly like Process; pass WithLookuper(MapLookuper{...}) to test a spec without
+// os.Setenv, or compose lookupers (MultiLookuper, FileRefLookuper, a DotEnvLookuper) to layer in
+// .env files or Docker/Kubernetes secret files.
+func ProcessWith(spec interface{}, opts ...Option) error {
+	cfg := config{lookuper: OSLookuper()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	// Check that spec is a pointer to struct
 	if reflect.ValueOf(spec).Kind() != reflect.Ptr {
-		return handleError("spec param must be a pointer to struct", showErrors)
+		return handleError("spec param must be a pointer to struct", cfg.showErrors)
 	}
 
 	// Get value from struct and dereference it
 	el := reflect.ValueOf(spec).Elem()
 
+	return combineErrors(processStruct(el, cfg.prefix, cfg.showErrors, cfg.lookuper))
+}
+
+// processStruct fills in a single struct level, recursing into nested structs (or pointers to
+// structs) so each sub-config can be defined and consumed independently while still composing into
+// one spec. Every field is attempted even after earlier ones fail, so the caller gets a complete
+// report rather than one error at a time.
+func processStruct(el reflect.Value, prefix string, showErrors bool, lookuper Lookuper) []error {
+	var errs []error
+
 	// For each field in spec struct, load relevant env var, and attempt to cast to the correct type
 	for i := 0; i < el.NumField(); i++ {
 		// Get the raw environment value based on env tag
 		typField := el.Type().Field(i)
+		fld := el.Field(i)
+
+		// A field implementing Value or encoding.TextUnmarshaler gets first refusal on its own env
+		// var, ahead of both the struct-recursion and scalar-conversion paths, so custom types
+		// (IP addresses, URLs, enums, big.Int, ...) are never mistaken for plain sub-configs
+		if setter, ok := customSetter(fld); ok {
+			envTag, ok := typField.Tag.Lookup("env")
+			if !ok {
+				errs = append(errs, fieldParseError("", typField.Name, fld.Type().String(), "", errors.New("'env' tag not found"), showErrors))
+				continue
+			}
+			envTag = prefixedEnvTag(prefix, envTag)
+
+			raw, err := resolveRaw(envTag, typField, showErrors, lookuper)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			if err := setter(raw); err != nil {
+				errs = append(errs, fieldParseError(envTag, typField.Name, fld.Type().String(), raw, err, showErrors))
+				continue
+			}
+
+			if validateTag := typField.Tag.Get("validate"); validateTag != "" {
+				if err := applyValidateTag(validateTag, fld, raw); err != nil {
+					errs = append(errs, fieldParseError(envTag, typField.Name, fld.Type().String(), raw, err, showErrors))
+				}
+			}
+			continue
+		}
+
+		// Nested structs are walked recursively, with their own env vars prefixed by the parent
+		// field's name (or an explicit `envprefix` tag) rather than being read directly
+		if fld.Kind() == reflect.Struct {
+			errs = append(errs, processStruct(fld, nestedPrefix(prefix, typField), showErrors, lookuper)...)
+			continue
+		}
+		if fld.Kind() == reflect.Ptr && fld.Type().Elem().Kind() == reflect.Struct {
+			if fld.IsNil() {
+				fld.Set(reflect.New(fld.Type().Elem()))
+			}
+			errs = append(errs, processStruct(fld.Elem(), nestedPrefix(prefix, typField), showErrors, lookuper)...)
+			continue
+		}
 
 		// Get env tag and ensure it was set
 		envTag, ok := typField.Tag.Lookup("env")
 		if !ok {
-			s := fmt.Sprintf("'env' tag not found for field %s", typField.Name)
-			return handleError(s, showErrors)
-		}
-
-		// Extract the value from the environment
-		raw := os.Getenv(envTag)
-		if raw == "" { // Check if Raw Env value is empty, if so we have a few fallback positions
-			if def := typField.Tag.Get("default"); def != "" {
-				// raw is missing, first check for a default setting
-				raw = def
-			} else if _, ok := typField.Tag.Lookup("required"); ok {
-				// no default, so check if required. If yes, we panic out since we cannot set this value
-				s := fmt.Sprintf("Env variable %s is required by field %s\n", envTag, typField.Name)
-				return handleError(s, showErrors)
-			}
+			errs = append(errs, fieldParseError("", typField.Name, fld.Type().String(), "", errors.New("'env' tag not found"), showErrors))
+			continue
 		}
+		envTag = prefixedEnvTag(prefix, envTag)
 
-		// Extract the concrete field for this iteration
-		fld := el.Field(i)
+		raw, err := resolveRaw(envTag, typField, showErrors, lookuper)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
 
+		separator := typField.Tag.Get("separator")
+		if separator == "" {
+			separator = defaultSeparator
+		}
+
+		var convErr error
 		switch fld.Type().Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			conv, err := strconv.Atoi(raw)
-			if err != nil {
-				s := fmt.Sprintf("Unable to convert value found in environment variable %s ('%s') to int. Aborting.", envTag, raw)
-				return handleError(s, showErrors)
-			}
+		case reflect.Slice:
+			convErr = setSlice(raw, fld, separator)
+		case reflect.Map:
+			convErr = setMap(raw, fld, separator)
+		default:
+			convErr = convertScalar(raw, fld)
+		}
 
-			fld.SetInt(int64(conv))
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			conv, err := strconv.ParseUint(raw, 10, 64)
-			if err != nil {
-				s := fmt.Sprintf("Unable to convert value found in environment variable %s ('%s') to uint. Aborting.", envTag, raw)
-				return handleError(s, showErrors)
+		if convErr == nil {
+			if validateTag := typField.Tag.Get("validate"); validateTag != "" {
+				convErr = applyValidateTag(validateTag, fld, raw)
 			}
+		}
 
-			fld.SetUint(uint64(conv))
-		case reflect.String:
-			fld.SetString(raw)
-		case reflect.Float32, reflect.Float64:
-			conv, err := strconv.ParseFloat(raw, 64)
-			if err != nil {
-				s := fmt.Sprintf("Unable to convert value found in environment variable %s ('%s') to float. Aborting.", envTag, raw)
-				return handleError(s, showErrors)
-			}
+		if convErr != nil {
+			errs = append(errs, fieldParseError(envTag, typField.Name, fld.Type().String(), raw, convErr, showErrors))
+		}
+	}
 
-			fld.SetFloat(conv)
-		case reflect.Bool:
-			switch raw {
-			case "0":
-				fld.SetBool(false)
-			case "1":
-				fld.SetBool(true)
-			default:
-				s := fmt.Sprintf("Unable to convert value found in environment variable %s ('%s') to bool (should be: 1 or 0). Aborting.", envTag, raw)
-				return handleError(s, showErrors)
-			}
+	if err := validateStruct(el, showErrors); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// resolveRaw looks up envTag via lookuper, falling back to a `default` tag, and reports a
+// required-but-missing field as a *ParseError.
+func resolveRaw(envTag string, typField reflect.StructField, showErrors bool, lookuper Lookuper) (string, error) {
+	raw, ok := lookuper.Lookup(envTag)
+	if !ok || raw == "" { // Check if Raw Env value is empty, if so we have a few fallback positions
+		if def := typField.Tag.Get("default"); def != "" {
+			// raw is missing, first check for a default setting
+			raw = def
+		} else if _, ok := typField.Tag.Lookup("required"); ok {
+			// no default, so check if required. If yes, report it since we cannot set this value
+			err := fieldParseError(envTag, typField.Name, typField.Type.String(), "", errors.New("required environment variable is not set"), showErrors)
+			return "", err
+		}
+	}
+
+	return raw, nil
+}
+
+// convertScalar parses raw into fld, which must be a settable field of a supported scalar kind
+// (the int/uint/float/string/bool families, plus time.Duration).
+func convertScalar(raw string, fld reflect.Value) error {
+	if fld.Kind() == reflect.Ptr {
+		if fld.IsNil() {
+			fld.Set(reflect.New(fld.Type().Elem()))
+		}
+
+		return convertScalar(raw, fld.Elem())
+	}
+
+	if fld.Type() == durationType {
+		conv, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("unable to convert %q to time.Duration: %w", raw, err)
+		}
+
+		fld.SetInt(int64(conv))
+		return nil
+	}
+
+	switch fld.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		conv, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("unable to convert %q to int: %w", raw, err)
+		}
+
+		fld.SetInt(int64(conv))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		conv, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to convert %q to uint: %w", raw, err)
+		}
+
+		fld.SetUint(conv)
+	case reflect.String:
+		fld.SetString(raw)
+	case reflect.Float32, reflect.Float64:
+		conv, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("unable to convert %q to float: %w", raw, err)
+		}
+
+		fld.SetFloat(conv)
+	case reflect.Bool:
+		switch raw {
+		case "0":
+			fld.SetBool(false)
+		case "1":
+			fld.SetBool(true)
+		default:
+			return fmt.Errorf("unable to convert %q to bool (should be: 1 or 0)", raw)
 		}
 	}
 
 	return nil
 }
 
+// setSlice splits raw on separator and converts each element via convertScalar, reusing the same
+// scalar conversion logic that backs plain fields so e.g. []int and []string both work.
+func setSlice(raw string, fld reflect.Value, separator string) error {
+	if raw == "" {
+		fld.Set(reflect.MakeSlice(fld.Type(), 0, 0))
+		return nil
+	}
+
+	parts := strings.Split(raw, separator)
+	out := reflect.MakeSlice(fld.Type(), len(parts), len(parts))
+
+	for i, part := range parts {
+		if err := convertScalar(strings.TrimSpace(part), out.Index(i)); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+
+	fld.Set(out)
+	return nil
+}
+
+// setMap splits raw on separator into "key:value" pairs and converts each side via convertScalar,
+// e.g. MYAPP_COLORS="red:1,green:2" into a map[string]int.
+func setMap(raw string, fld reflect.Value, separator string) error {
+	if raw == "" {
+		fld.Set(reflect.MakeMapWithSize(fld.Type(), 0))
+		return nil
+	}
+
+	pairs := strings.Split(raw, separator)
+	out := reflect.MakeMapWithSize(fld.Type(), len(pairs))
+	keyType, valType := fld.Type().Key(), fld.Type().Elem()
+
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("entry %q: expected key:value", pair)
+		}
+
+		key := reflect.New(keyType).Elem()
+		if err := convertScalar(strings.TrimSpace(kv[0]), key); err != nil {
+			return fmt.Errorf("entry %q: key: %w", pair, err)
+		}
+
+		val := reflect.New(valType).Elem()
+		if err := convertScalar(strings.TrimSpace(kv[1]), val); err != nil {
+			return fmt.Errorf("entry %q: value: %w", pair, err)
+		}
+
+		out.SetMapIndex(key, val)
+	}
+
+	fld.Set(out)
+	return nil
+}
+
 // LoadConfig present for backwards compatibility
 func LoadConfig(cfg interface{}, showErrors bool) (interface{}, error) {
 	err := Process(cfg, showErrors)
 	return cfg, err
 }
-
-
-
@@ -1,9 +1,12 @@
 package envconfig
 
 import (
+	"errors"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 type customValue struct {
@@ -40,3 +43,232 @@ func TestLoader(t *testing.T) {
 		t.Errorf("expected Val to be loremloremlorem, got %s", spec.Val.v)
 	}
 }
+
+type redisSettings struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT" default:"6379"`
+}
+
+type nestedSpec struct {
+	Redis   redisSettings
+	Backup  *redisSettings `envprefix:"BACKUP_REDIS"`
+	AppName string         `env:"APP_NAME"`
+}
+
+func TestLoaderNestedStruct(t *testing.T) {
+	os.Setenv("REDIS_HOST", "redis.internal")
+	os.Setenv("BACKUP_REDIS_HOST", "redis-backup.internal")
+	os.Setenv("APP_NAME", "lorem")
+
+	var spec nestedSpec
+	if err := Process(&spec, true); err != nil {
+		t.Errorf("processing failed with error: %v", err)
+	}
+
+	if spec.Redis.Host != "redis.internal" {
+		t.Errorf("expected Redis.Host to be redis.internal, got %s", spec.Redis.Host)
+	}
+
+	if spec.Redis.Port != 6379 {
+		t.Errorf("expected Redis.Port to be 6379, got %d", spec.Redis.Port)
+	}
+
+	if spec.Backup == nil || spec.Backup.Host != "redis-backup.internal" {
+		t.Errorf("expected Backup.Host to be redis-backup.internal, got %+v", spec.Backup)
+	}
+
+	if spec.AppName != "lorem" {
+		t.Errorf("expected AppName to be lorem, got %s", spec.AppName)
+	}
+}
+
+func TestProcessWithPrefix(t *testing.T) {
+	os.Setenv("MYAPP_APP_NAME", "ipsum")
+	os.Setenv("MYAPP_REDIS_HOST", "redis.myapp")
+
+	var spec nestedSpec
+	spec.Backup = &redisSettings{}
+	os.Setenv("MYAPP_BACKUP_REDIS_HOST", "redis-backup.myapp")
+
+	if err := ProcessWithPrefix("MYAPP", &spec, true); err != nil {
+		t.Errorf("processing failed with error: %v", err)
+	}
+
+	if spec.AppName != "ipsum" {
+		t.Errorf("expected AppName to be ipsum, got %s", spec.AppName)
+	}
+
+	if spec.Redis.Host != "redis.myapp" {
+		t.Errorf("expected Redis.Host to be redis.myapp, got %s", spec.Redis.Host)
+	}
+}
+
+type collectionSpec struct {
+	Ports   []int             `env:"PORTS"`
+	Tags    []string          `env:"TAGS" separator:"|"`
+	Colors  map[string]int    `env:"COLORS"`
+	Timeout time.Duration     `env:"TIMEOUT"`
+	Labels  map[string]string `env:"LABELS" separator:";"`
+}
+
+func TestLoaderCollections(t *testing.T) {
+	os.Setenv("PORTS", "80,443,8080")
+	os.Setenv("TAGS", "a|b|c")
+	os.Setenv("COLORS", "red:1,green:2")
+	os.Setenv("TIMEOUT", "1500ms")
+	os.Setenv("LABELS", "env:prod;team:core")
+
+	var spec collectionSpec
+	if err := Process(&spec, true); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if !reflect.DeepEqual(spec.Ports, []int{80, 443, 8080}) {
+		t.Errorf("expected Ports to be [80 443 8080], got %v", spec.Ports)
+	}
+
+	if !reflect.DeepEqual(spec.Tags, []string{"a", "b", "c"}) {
+		t.Errorf("expected Tags to be [a b c], got %v", spec.Tags)
+	}
+
+	if !reflect.DeepEqual(spec.Colors, map[string]int{"red": 1, "green": 2}) {
+		t.Errorf("expected Colors to be map[red:1 green:2], got %v", spec.Colors)
+	}
+
+	if spec.Timeout != 1500*time.Millisecond {
+		t.Errorf("expected Timeout to be 1.5s, got %s", spec.Timeout)
+	}
+
+	if !reflect.DeepEqual(spec.Labels, map[string]string{"env": "prod", "team": "core"}) {
+		t.Errorf("expected Labels to be map[env:prod team:core], got %v", spec.Labels)
+	}
+}
+
+func TestLoaderUnsetCollectionsAreEmptyNotNil(t *testing.T) {
+	os.Unsetenv("TAGS")
+	os.Unsetenv("LABELS")
+
+	var spec collectionSpec
+	if err := Process(&spec, true); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if spec.Tags == nil || len(spec.Tags) != 0 {
+		t.Errorf("expected Tags to be an empty, non-nil slice, got %#v", spec.Tags)
+	}
+
+	if spec.Labels == nil || len(spec.Labels) != 0 {
+		t.Errorf("expected Labels to be an empty, non-nil map, got %#v", spec.Labels)
+	}
+}
+
+type upperCaseText string
+
+func (u *upperCaseText) UnmarshalText(text []byte) error {
+	*u = upperCaseText(strings.ToUpper(string(text)))
+	return nil
+}
+
+type textUnmarshalerSpec struct {
+	Region upperCaseText `env:"REGION"`
+}
+
+func TestLoaderTextUnmarshaler(t *testing.T) {
+	os.Setenv("REGION", "us-east-1")
+
+	var spec textUnmarshalerSpec
+	if err := Process(&spec, true); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if spec.Region != "US-EAST-1" {
+		t.Errorf("expected Region to be US-EAST-1, got %s", spec.Region)
+	}
+}
+
+type pointerScalarSpec struct {
+	Port *int `env:"PTR_PORT"`
+}
+
+func TestLoaderPointerToScalar(t *testing.T) {
+	os.Setenv("PTR_PORT", "5")
+
+	var spec pointerScalarSpec
+	if err := Process(&spec, true); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if spec.Port == nil || *spec.Port != 5 {
+		t.Errorf("expected Port to point to 5, got %v", spec.Port)
+	}
+}
+
+func TestLoaderUnsetPointerToScalarErrors(t *testing.T) {
+	os.Unsetenv("PTR_PORT")
+
+	var spec pointerScalarSpec
+	err := Process(&spec, false)
+	if err == nil {
+		t.Fatal("expected an error for an unset, non-string pointer field, got nil")
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.FieldName != "Port" {
+		t.Fatalf("expected a *ParseError for field Port, got %v", err)
+	}
+}
+
+type multiFieldSpec struct {
+	Port    int    `env:"MULTI_PORT"`
+	Timeout int    `env:"MULTI_TIMEOUT"`
+	Name    string `env:"MULTI_NAME"`
+}
+
+func TestProcessAggregatesAllFieldErrors(t *testing.T) {
+	os.Setenv("MULTI_PORT", "not-a-number")
+	os.Setenv("MULTI_TIMEOUT", "also-not-a-number")
+	os.Setenv("MULTI_NAME", "lorem")
+
+	var spec multiFieldSpec
+	err := Process(&spec, false)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("expected a *MultiError, got %T: %v", err, err)
+	}
+
+	if len(multi.Errors) != 2 {
+		t.Fatalf("expected 2 aggregated errors, got %d: %v", len(multi.Errors), multi.Errors)
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected errors.As to find a *ParseError, got %v", err)
+	}
+
+	if spec.Name != "lorem" {
+		t.Errorf("expected Name to still be populated despite earlier field errors, got %s", spec.Name)
+	}
+}
+
+func TestProcessSingleFieldErrorIsNotMultiError(t *testing.T) {
+	os.Setenv("MULTI_PORT", "not-a-number")
+	os.Setenv("MULTI_TIMEOUT", "42")
+	os.Setenv("MULTI_NAME", "lorem")
+
+	var spec multiFieldSpec
+	err := Process(&spec, false)
+
+	var multi *MultiError
+	if errors.As(err, &multi) {
+		t.Fatalf("expected a lone *ParseError, not a *MultiError: %v", err)
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.FieldName != "Port" {
+		t.Fatalf("expected a *ParseError for field Port, got %v", err)
+	}
+}
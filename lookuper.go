@@ -0,0 +1,131 @@
+package envconfig
+
+import (
+	"os"
+	"strings"
+)
+
+// Lookuper abstracts where Process reads a variable's raw value from. The zero-value entrypoints
+// (Process, ProcessWithPrefix) use OSLookuper; ProcessWith lets a caller substitute or combine others,
+// which removes the hard os.Getenv coupling and makes specs unit-testable without os.Setenv.
+type Lookuper interface {
+	// Lookup returns the raw value for key and whether it was present at all.
+	Lookup(key string) (string, bool)
+}
+
+// osLookuper reads from the real process environment.
+type osLookuper struct{}
+
+func (osLookuper) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// OSLookuper returns a Lookuper backed by os.LookupEnv.
+func OSLookuper() Lookuper {
+	return osLookuper{}
+}
+
+// MapLookuper is a Lookuper backed by a plain map, primarily useful for tests that want to supply
+// env values without touching the real process environment.
+type MapLookuper map[string]string
+
+func (m MapLookuper) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// multiLookuper tries each of its Lookupers in order and returns the first hit.
+type multiLookuper struct {
+	lookupers []Lookuper
+}
+
+// MultiLookuper combines several Lookupers, trying each in order and returning the first one that
+// has the requested key.
+func MultiLookuper(lookupers ...Lookuper) Lookuper {
+	return multiLookuper{lookupers: lookupers}
+}
+
+func (m multiLookuper) Lookup(key string) (string, bool) {
+	for _, l := range m.lookupers {
+		if v, ok := l.Lookup(key); ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// DotEnvLookuper parses a .env file (KEY=VALUE lines, blank lines and "#" comments ignored, values
+// may be wrapped in matching single or double quotes) into a MapLookuper.
+func DotEnvLookuper(path string) (Lookuper, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(MapLookuper)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		vars[strings.TrimSpace(key)] = unquote(strings.TrimSpace(val))
+	}
+
+	return vars, nil
+}
+
+// unquote strips a single matching pair of surrounding quotes from a .env value, if present.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+
+	first, last := s[0], s[len(s)-1]
+	if (first == '"' || first == '\'') && first == last {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+// fileRefSuffix marks a variable whose value is actually a path to a file holding the real value,
+// the convention Docker and Kubernetes use to mount secrets.
+const fileRefSuffix = "_FILE"
+
+// fileRefLookuper wraps another Lookuper, resolving KEY by reading the file named by KEY_FILE when
+// KEY itself isn't set directly.
+type fileRefLookuper struct {
+	next Lookuper
+}
+
+// FileRefLookuper wraps next so that any variable ending in "_FILE" also satisfies a lookup for its
+// un-suffixed name by reading the referenced file, e.g. DB_PASSWORD_FILE=/run/secrets/db_password
+// lets a spec tagged `env:"DB_PASSWORD"` read the secret's contents without any wrapper code.
+func FileRefLookuper(next Lookuper) Lookuper {
+	return fileRefLookuper{next: next}
+}
+
+func (f fileRefLookuper) Lookup(key string) (string, bool) {
+	if v, ok := f.next.Lookup(key); ok {
+		return v, true
+	}
+
+	path, ok := f.next.Lookup(key + fileRefSuffix)
+	if !ok {
+		return "", false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	return strings.TrimSpace(string(data)), true
+}
@@ -0,0 +1,87 @@
+package envconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type lookuperSpec struct {
+	Host     string `env:"HOST"`
+	Password string `env:"PASSWORD"`
+}
+
+func TestProcessWithMapLookuper(t *testing.T) {
+	var spec lookuperSpec
+	lookuper := MapLookuper{"HOST": "db.internal", "PASSWORD": "hunter2"}
+
+	if err := ProcessWith(&spec, WithLookuper(lookuper), WithShowErrors(true)); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if spec.Host != "db.internal" || spec.Password != "hunter2" {
+		t.Errorf("expected spec to be populated from MapLookuper, got %+v", spec)
+	}
+}
+
+func TestFileRefLookuper(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "password")
+	if err := os.WriteFile(secretPath, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	base := MapLookuper{"HOST": "db.internal", "PASSWORD_FILE": secretPath}
+	lookuper := FileRefLookuper(base)
+
+	var spec lookuperSpec
+	if err := ProcessWith(&spec, WithLookuper(lookuper)); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if spec.Password != "hunter2" {
+		t.Errorf("expected Password to be resolved from PASSWORD_FILE, got %q", spec.Password)
+	}
+}
+
+func TestDotEnvLookuper(t *testing.T) {
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, ".env")
+	contents := "# a comment\nHOST=db.internal\nPASSWORD=\"hunter2\"\n\n"
+	if err := os.WriteFile(envPath, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write .env file: %v", err)
+	}
+
+	lookuper, err := DotEnvLookuper(envPath)
+	if err != nil {
+		t.Fatalf("DotEnvLookuper failed: %v", err)
+	}
+
+	var spec lookuperSpec
+	if err := ProcessWith(&spec, WithLookuper(lookuper)); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if spec.Host != "db.internal" || spec.Password != "hunter2" {
+		t.Errorf("expected spec to be populated from .env file, got %+v", spec)
+	}
+}
+
+func TestMultiLookuperPrefersEarlierSource(t *testing.T) {
+	primary := MapLookuper{"HOST": "primary.internal"}
+	fallback := MapLookuper{"HOST": "fallback.internal", "PASSWORD": "hunter2"}
+	lookuper := MultiLookuper(primary, fallback)
+
+	var spec lookuperSpec
+	if err := ProcessWith(&spec, WithLookuper(lookuper)); err != nil {
+		t.Fatalf("processing failed with error: %v", err)
+	}
+
+	if spec.Host != "primary.internal" {
+		t.Errorf("expected Host to come from the primary lookuper, got %q", spec.Host)
+	}
+
+	if spec.Password != "hunter2" {
+		t.Errorf("expected Password to fall through to the fallback lookuper, got %q", spec.Password)
+	}
+}
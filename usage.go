@@ -0,0 +1,113 @@
+package envconfig
+
+import (
+	"encoding"
+	"errors"
+	"io"
+	"reflect"
+	"text/tabwriter"
+	"text/template"
+)
+
+var (
+	valueType           = reflect.TypeOf((*Value)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// VarUsage describes a single environment variable as Process would read it, for use in a Usage
+// template.
+type VarUsage struct {
+	Key      string
+	Type     string
+	Required bool
+	Default  string
+	Desc     string
+}
+
+// DefaultUsageTemplate is the template Usage renders with. It is a tab-separated table piped through
+// a text/tabwriter, listing every variable's key, type, whether it's required, its default, and its
+// `desc` tag.
+const DefaultUsageTemplate = `KEY	TYPE	REQUIRED	DEFAULT	DESCRIPTION
+{{range .}}{{.Key}}	{{.Type}}	{{if .Required}}yes{{else}}no{{end}}	{{.Default}}	{{.Desc}}
+{{end}}`
+
+// Usage walks spec the same way Process would and prints a tabular summary of every environment
+// variable it expects: its name, type, whether it's required, its default value, and a `desc` tag
+// comment. Nested structs (see ProcessWithPrefix) are included with their computed prefixes.
+func Usage(spec interface{}, w io.Writer) error {
+	return Usagef(spec, w, DefaultUsageTemplate)
+}
+
+// Usagef works like Usage but renders the collected variables through a caller-supplied
+// text/template instead of DefaultUsageTemplate. The template is executed with a []VarUsage.
+func Usagef(spec interface{}, w io.Writer, tpl string) error {
+	t := reflect.TypeOf(spec)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return errors.New("spec param must be a pointer to struct")
+	}
+
+	vars := collectVarUsage(t.Elem(), "")
+
+	tmpl, err := template.New("usage").Parse(tpl)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if err := tmpl.Execute(tw, vars); err != nil {
+		return err
+	}
+
+	return tw.Flush()
+}
+
+// collectVarUsage is the type-only counterpart to processStruct: it never needs an actual spec
+// value, just the struct shape, so it walks reflect.Type instead of reflect.Value and never
+// allocates anything.
+func collectVarUsage(t reflect.Type, prefix string) []VarUsage {
+	var vars []VarUsage
+
+	for i := 0; i < t.NumField(); i++ {
+		typField := t.Field(i)
+		fieldType := typField.Type
+
+		if !implementsCustomType(fieldType) {
+			if fieldType.Kind() == reflect.Struct {
+				vars = append(vars, collectVarUsage(fieldType, nestedPrefix(prefix, typField))...)
+				continue
+			}
+			if fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Struct {
+				vars = append(vars, collectVarUsage(fieldType.Elem(), nestedPrefix(prefix, typField))...)
+				continue
+			}
+		}
+
+		envTag, ok := typField.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		_, required := typField.Tag.Lookup("required")
+		vars = append(vars, VarUsage{
+			Key:      prefixedEnvTag(prefix, envTag),
+			Type:     fieldType.String(),
+			Required: required,
+			Default:  typField.Tag.Get("default"),
+			Desc:     typField.Tag.Get("desc"),
+		})
+	}
+
+	return vars
+}
+
+// implementsCustomType reports whether t (or *t) implements Value or encoding.TextUnmarshaler, the
+// same check customSetter performs on a live reflect.Value, so Usage lists such fields as leaf env
+// vars rather than recursing into them as sub-configs.
+func implementsCustomType(t reflect.Type) bool {
+	if t.Implements(valueType) || t.Implements(textUnmarshalerType) {
+		return true
+	}
+
+	pt := reflect.PointerTo(t)
+	return pt.Implements(valueType) || pt.Implements(textUnmarshalerType)
+}
@@ -0,0 +1,45 @@
+package envconfig
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type usageRedis struct {
+	Host string `env:"HOST" desc:"redis host to connect to"`
+	Port int    `env:"PORT" default:"6379" desc:"redis port"`
+}
+
+type usageSpec struct {
+	Redis   usageRedis
+	AppName string `env:"APP_NAME" required:"true" desc:"name of the running service"`
+}
+
+func TestUsage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Usage(&usageSpec{}, &buf); err != nil {
+		t.Fatalf("Usage failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"REDIS_HOST", "REDIS_PORT", "6379", "APP_NAME", "yes", "redis host to connect to"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestUsagefCustomTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	tpl := `{{range .}}{{.Key}}={{.Default}}
+{{end}}`
+
+	if err := Usagef(&usageSpec{}, &buf, tpl); err != nil {
+		t.Fatalf("Usagef failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "REDIS_PORT=6379") {
+		t.Errorf("expected custom template output to contain REDIS_PORT=6379, got: %s", buf.String())
+	}
+}
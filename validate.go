@@ -0,0 +1,128 @@
+package envconfig
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Validatable lets a spec (or any nested struct field) run its own post-processing checks once
+// Process has finished populating it. Validate is invoked after every field in that struct has been
+// set, and any error it returns is aggregated into the same MultiError as parse failures.
+type Validatable interface {
+	Validate() error
+}
+
+// emailPattern is a deliberately loose email shape check, not a full RFC 5322 validator.
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// applyValidateTag runs each comma-separated predicate in a `validate` tag (nonzero, min=, max=,
+// oneof=, url, email) against the field's converted value and its raw string form.
+func applyValidateTag(tag string, fld reflect.Value, raw string) error {
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(rule, "=")
+		if err := applyValidateRule(name, arg, fld, raw); err != nil {
+			return fmt.Errorf("validate %q: %w", rule, err)
+		}
+	}
+
+	return nil
+}
+
+func applyValidateRule(name, arg string, fld reflect.Value, raw string) error {
+	switch name {
+	case "nonzero":
+		if fld.IsZero() {
+			return fmt.Errorf("must not be the zero value")
+		}
+	case "min":
+		return checkBound(fld, arg, func(v, limit float64) bool { return v >= limit }, "must be >= %s")
+	case "max":
+		return checkBound(fld, arg, func(v, limit float64) bool { return v <= limit }, "must be <= %s")
+	case "oneof":
+		for _, option := range strings.Split(arg, "|") {
+			if raw == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of [%s]", arg)
+	case "url":
+		if _, err := url.ParseRequestURI(raw); err != nil {
+			return fmt.Errorf("must be a valid url: %w", err)
+		}
+	case "email":
+		if !emailPattern.MatchString(raw) {
+			return fmt.Errorf("must be a valid email address")
+		}
+	default:
+		return fmt.Errorf("unknown validate rule %q", name)
+	}
+
+	return nil
+}
+
+// checkBound compares a field's numeric value (or a string field's length) against arg using cmp,
+// used by both "min" and "max".
+func checkBound(fld reflect.Value, arg string, cmp func(v, limit float64) bool, msg string) error {
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("invalid bound %q: %w", arg, err)
+	}
+
+	v, ok := numericValue(fld)
+	if !ok {
+		return fmt.Errorf("not supported for type %s", fld.Type())
+	}
+
+	if !cmp(v, limit) {
+		return fmt.Errorf(msg, arg)
+	}
+
+	return nil
+}
+
+// numericValue extracts a comparable float64 out of fld: its numeric value for int/uint/float
+// kinds, or its length for a string, matching how most struct validators treat min/max on strings.
+func numericValue(fld reflect.Value) (float64, bool) {
+	switch fld.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fld.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fld.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return fld.Float(), true
+	case reflect.String:
+		return float64(len(fld.String())), true
+	case reflect.Slice, reflect.Map:
+		return float64(fld.Len()), true
+	default:
+		return 0, false
+	}
+}
+
+// validateStruct invokes el's own Validate method, if it (via its pointer) implements Validatable,
+// and reports any failure as a *ParseError.
+func validateStruct(el reflect.Value, showErrors bool) error {
+	if !el.CanAddr() {
+		return nil
+	}
+
+	v, ok := el.Addr().Interface().(Validatable)
+	if !ok {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return fieldParseError("", el.Type().Name(), el.Type().String(), "", err, showErrors)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+type validateTagSpec struct {
+	Port  int    `env:"VALIDATE_PORT" validate:"min=1,max=65535"`
+	Env   string `env:"VALIDATE_ENV" validate:"oneof=dev|staging|prod"`
+	Email string `env:"VALIDATE_EMAIL" validate:"email"`
+}
+
+func TestValidateTagRejectsOutOfRange(t *testing.T) {
+	os.Setenv("VALIDATE_PORT", "99999")
+	os.Setenv("VALIDATE_ENV", "dev")
+	os.Setenv("VALIDATE_EMAIL", "ops@example.com")
+
+	var spec validateTagSpec
+	err := Process(&spec, false)
+	if err == nil {
+		t.Fatal("expected an error for Port out of range, got nil")
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.FieldName != "Port" {
+		t.Fatalf("expected a *ParseError for field Port, got %v", err)
+	}
+}
+
+func TestValidateTagAcceptsValidSpec(t *testing.T) {
+	os.Setenv("VALIDATE_PORT", "8080")
+	os.Setenv("VALIDATE_ENV", "prod")
+	os.Setenv("VALIDATE_EMAIL", "ops@example.com")
+
+	var spec validateTagSpec
+	if err := Process(&spec, false); err != nil {
+		t.Fatalf("expected valid spec to process cleanly, got %v", err)
+	}
+}
+
+func TestValidateTagRejectsBadOneOf(t *testing.T) {
+	os.Setenv("VALIDATE_PORT", "8080")
+	os.Setenv("VALIDATE_ENV", "nope")
+	os.Setenv("VALIDATE_EMAIL", "ops@example.com")
+
+	var spec validateTagSpec
+	if err := Process(&spec, false); err == nil {
+		t.Fatal("expected an error for an out-of-set Env, got nil")
+	}
+}
+
+type validatableSpec struct {
+	MinPort int `env:"VALIDATABLE_MIN"`
+	MaxPort int `env:"VALIDATABLE_MAX"`
+}
+
+func (v *validatableSpec) Validate() error {
+	if v.MinPort > v.MaxPort {
+		return errors.New("MinPort must not exceed MaxPort")
+	}
+
+	return nil
+}
+
+func TestValidatableHookRuns(t *testing.T) {
+	os.Setenv("VALIDATABLE_MIN", "100")
+	os.Setenv("VALIDATABLE_MAX", "10")
+
+	var spec validatableSpec
+	err := Process(&spec, false)
+	if err == nil {
+		t.Fatal("expected Validate to reject MinPort > MaxPort, got nil")
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected a *ParseError wrapping the Validate failure, got %v", err)
+	}
+}
+
+func TestValidatableHookPasses(t *testing.T) {
+	os.Setenv("VALIDATABLE_MIN", "10")
+	os.Setenv("VALIDATABLE_MAX", "100")
+
+	var spec validatableSpec
+	if err := Process(&spec, false); err != nil {
+		t.Fatalf("expected Validate to pass, got %v", err)
+	}
+}